@@ -0,0 +1,132 @@
+// Package gradient maps a value in [0, 1] (typically a cell's normalized
+// age) to a color along a configurable ramp.
+package gradient
+
+import (
+	"fmt"
+	"image/color"
+	"strconv"
+	"strings"
+)
+
+// Mode selects how a Gradient interpolates between its Stops.
+type Mode int
+
+const (
+	// Linear interpolates directly between the first and last stop,
+	// ignoring any in between.
+	Linear Mode = iota
+	// Poly (polylinear) walks piecewise-linearly through every stop in
+	// order.
+	Poly
+	// Bezier treats the stops as control points and evaluates De
+	// Casteljau's algorithm.
+	Bezier
+)
+
+// ParseMode parses the --gradient flag values.
+func ParseMode(s string) (Mode, error) {
+	switch s {
+	case "linear":
+		return Linear, nil
+	case "poly":
+		return Poly, nil
+	case "bezier":
+		return Bezier, nil
+	default:
+		return 0, fmt.Errorf("gradient: unknown mode %q (want linear, poly or bezier)", s)
+	}
+}
+
+// Gradient is a color ramp over a sequence of stops.
+type Gradient struct {
+	Mode  Mode
+	Stops []color.RGBA
+}
+
+// At returns the color at position t, clamped to [0, 1].
+func (g Gradient) At(t float64) color.RGBA {
+	if len(g.Stops) == 0 {
+		return color.RGBA{A: 255}
+	}
+	if t < 0 {
+		t = 0
+	} else if t > 1 {
+		t = 1
+	}
+	switch g.Mode {
+	case Poly:
+		return g.polylinear(t)
+	case Bezier:
+		return g.bezier(t)
+	default:
+		return lerp(g.Stops[0], g.Stops[len(g.Stops)-1], t)
+	}
+}
+
+func (g Gradient) polylinear(t float64) color.RGBA {
+	if len(g.Stops) == 1 {
+		return g.Stops[0]
+	}
+	segments := len(g.Stops) - 1
+	pos := t * float64(segments)
+	i := int(pos)
+	if i >= segments {
+		i = segments - 1
+	}
+	return lerp(g.Stops[i], g.Stops[i+1], pos-float64(i))
+}
+
+// bezier evaluates De Casteljau's algorithm, treating every stop as a
+// Bezier control point.
+func (g Gradient) bezier(t float64) color.RGBA {
+	pts := make([]color.RGBA, len(g.Stops))
+	copy(pts, g.Stops)
+	for len(pts) > 1 {
+		for i := 0; i < len(pts)-1; i++ {
+			pts[i] = lerp(pts[i], pts[i+1], t)
+		}
+		pts = pts[:len(pts)-1]
+	}
+	return pts[0]
+}
+
+func lerp(a, b color.RGBA, t float64) color.RGBA {
+	return color.RGBA{
+		R: lerpByte(a.R, b.R, t),
+		G: lerpByte(a.G, b.G, t),
+		B: lerpByte(a.B, b.B, t),
+		A: 255,
+	}
+}
+
+func lerpByte(a, b uint8, t float64) uint8 {
+	return uint8(float64(a) + (float64(b)-float64(a))*t)
+}
+
+// ParseStops parses a comma-separated list of "#rrggbb" colors, as taken by
+// the --colors flag.
+func ParseStops(s string) ([]color.RGBA, error) {
+	parts := strings.Split(s, ",")
+	stops := make([]color.RGBA, 0, len(parts))
+	for _, p := range parts {
+		c, err := parseHexColor(strings.TrimSpace(p))
+		if err != nil {
+			return nil, err
+		}
+		stops = append(stops, c)
+	}
+	return stops, nil
+}
+
+func parseHexColor(s string) (color.RGBA, error) {
+	s = strings.TrimPrefix(s, "#")
+	if len(s) != 6 {
+		return color.RGBA{}, fmt.Errorf("gradient: invalid color %q", s)
+	}
+	v, err := strconv.ParseUint(s, 16, 32)
+	if err != nil {
+		return color.RGBA{}, fmt.Errorf("gradient: invalid color %q: %w", s, err)
+	}
+	return color.RGBA{R: uint8(v >> 16), G: uint8(v >> 8), B: uint8(v), A: 255}, nil
+}
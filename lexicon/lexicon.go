@@ -0,0 +1,81 @@
+// Package lexicon fetches and caches the searchable index of patterns
+// published at https://playgameoflife.com/lexicon/.
+package lexicon
+
+import (
+	_ "embed"
+	"encoding/json"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+)
+
+//go:embed index.json
+var bundledIndex []byte
+
+// Entry is one pattern listed in the Life Lexicon index. Code is the
+// lexicon identifier used to fetch the full pattern definition, e.g. via
+// https://playgameoflife.com/lexicon/data/<Code>.json.
+type Entry struct {
+	Code        string
+	Name        string
+	Description string
+}
+
+const indexURL = "https://playgameoflife.com/lexicon/data/index.json"
+
+func cachePath() (string, error) {
+	dir, err := os.UserCacheDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(dir, "gameoflife", "lexicon-index.json"), nil
+}
+
+func decode(data []byte) ([]Entry, error) {
+	var entries []Entry
+	if err := json.Unmarshal(data, &entries); err != nil {
+		return nil, err
+	}
+	return entries, nil
+}
+
+// Load returns the lexicon index, preferring a previously cached copy so
+// that browsing works offline, and otherwise falling back to the index
+// bundled with the binary.
+func Load() ([]Entry, error) {
+	if path, err := cachePath(); err == nil {
+		if data, err := os.ReadFile(path); err == nil {
+			if entries, err := decode(data); err == nil {
+				return entries, nil
+			}
+		}
+	}
+	return decode(bundledIndex)
+}
+
+// Refresh fetches the latest index over the network and caches it under
+// os.UserCacheDir() for future offline use.
+func Refresh() ([]Entry, error) {
+	res, err := http.Get(indexURL)
+	if err != nil {
+		return nil, err
+	}
+	defer res.Body.Close()
+	data, err := io.ReadAll(res.Body)
+	if err != nil {
+		return nil, err
+	}
+	entries, err := decode(data)
+	if err != nil {
+		return nil, err
+	}
+
+	if path, err := cachePath(); err == nil {
+		if err := os.MkdirAll(filepath.Dir(path), 0o755); err == nil {
+			_ = os.WriteFile(path, data, 0o644)
+		}
+	}
+	return entries, nil
+}
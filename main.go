@@ -3,10 +3,12 @@ package main
 
 import (
 	"encoding/json"
+	"flag"
 	"fmt"
 	"image/color"
 	"io"
 	"log"
+	"math"
 	"net/http"
 	"os"
 	"strings"
@@ -20,34 +22,72 @@ import (
 	"github.com/hajimehoshi/ebiten/v2/vector"
 	"golang.org/x/image/font"
 	"golang.org/x/image/font/opentype"
+
+	"github.com/fpbouchard/gameoflife/gradient"
+	"github.com/fpbouchard/gameoflife/lexicon"
+	"github.com/fpbouchard/gameoflife/pattern"
+	"github.com/fpbouchard/gameoflife/widget"
+	"github.com/fpbouchard/gameoflife/world"
 )
 
 const (
+	defaultAgeCap       = 32
+	defaultCellPixels   = 4
+	defaultColors       = "#ffffff,#ffaa00,#3355ff"
+	defaultGradientMode = "linear"
 	logicalScreenFactor = 2
 	logicalScreenHeight = screenHeight / logicalScreenFactor
 	logicalScreenWidth  = screenWidth / logicalScreenFactor
+	maxCellPixels       = 64.0
+	minCellPixels       = 0.25
 	patternEditorScale  = 20
+	rlePatternPath      = "pattern.rle"
 	screenHeight        = 960
 	screenWidth         = 1280
+	zoomStep            = 1.1
 )
 
+// gradientModeOrder is the cycle order for the in-game gradient hotkey.
+var gradientModeOrder = []gradient.Mode{gradient.Linear, gradient.Poly, gradient.Bezier}
+
 var (
 	mplusNormalFont font.Face
 )
 
+// Viewport maps the infinite world to the logical screen: CellPixels cells
+// are drawn CellPixels logical pixels wide, centered on (CenterX, CenterY).
+type Viewport struct {
+	CenterX    float64
+	CenterY    float64
+	CellPixels float64
+}
+
 type Game struct {
 	active             bool
-	cells              []bool
+	ageCap             uint16
+	browser            *patternBrowser
+	browserVisible     bool
 	editorVisible      bool
+	gradient           gradient.Gradient
 	lastUpdateTime     time.Time
+	lexiconRefresh     chan []lexicon.Entry
+	panning            bool
+	panLastX           int
+	panLastY           int
 	pattern            []bool
 	patternEditorScale int
 	patternHeight      int
 	patternWidth       int
+	root               *widget.Root
+	ruleIndex          int
+	rules              pattern.Rules
 	showTPS            bool
 	speed              time.Duration
 	terminated         bool
+	toroidal           bool
+	viewport           Viewport
 	welcomeScreen      bool
+	world              *world.World
 }
 
 type PatternDefinition struct {
@@ -59,19 +99,209 @@ type PatternDefinition struct {
 	Pattern     string
 }
 
-func (g *Game) index(x, y int) int {
-	return y*logicalScreenWidth + x
+// patternBrowser is the Life Lexicon search overlay: a widget.Window
+// holding a search box, a scrollable incrementally-filtered list of
+// entries, and a live preview of whichever entry is highlighted. Moving the
+// selection (click or up/down) previews it; confirming it (enter, or the
+// Load button) loads it as the editor pattern. It implements widget.Widget
+// so it can sit in Game.root alongside future overlays.
+type patternBrowser struct {
+	window   *widget.Window
+	list     *widget.List
+	preview  *widget.Grid
+	entries  []lexicon.Entry
+	filtered []lexicon.Entry
+	query    string
+
+	previewCode  string
+	previewW     int
+	previewH     int
+	previewCells []bool
+	fetched      chan previewResult
+}
+
+// previewResult is the outcome of fetching the full pattern for one lexicon
+// code, delivered back to the main loop over patternBrowser.fetched so the
+// background HTTP call never touches widget state directly.
+type previewResult struct {
+	code   string
+	width  int
+	height int
+	cells  []bool
+}
+
+func newPatternBrowser(entries []lexicon.Entry, onChoose func(code string)) *patternBrowser {
+	pb := &patternBrowser{entries: entries, fetched: make(chan previewResult, 1)}
+
+	choose := func(index int) {
+		if index >= 0 && index < len(pb.filtered) {
+			onChoose(pb.filtered[index].Code)
+		}
+	}
+
+	pb.preview = &widget.Grid{X: 40, Y: logicalScreenHeight - 170, CellSize: 2}
+	pb.preview.Alive = func(x, y int) bool {
+		if pb.previewCells == nil || x >= pb.previewW || y >= pb.previewH {
+			return false
+		}
+		return pb.previewCells[y*pb.previewW+x]
+	}
+
+	pb.list = &widget.List{
+		X: 40, Y: 84, W: logicalScreenWidth - 80, H: logicalScreenHeight - 300,
+		RowHeight: 18,
+		Font:      mplusNormalFont,
+		OnSelect:  pb.startPreview,
+		OnConfirm: choose,
+	}
+	search := &widget.TextInput{
+		X: 40, Y: 50, W: logicalScreenWidth - 80, H: 24,
+		Font:     mplusNormalFont,
+		OnChange: pb.filter,
+	}
+	loadButton := &widget.Button{
+		X: logicalScreenWidth - 160, Y: logicalScreenHeight - 68, W: 120, H: 28,
+		Label:   "Load selected",
+		Font:    mplusNormalFont,
+		OnClick: func() { choose(pb.list.Selected) },
+	}
+	pb.filter("")
+
+	pb.window = &widget.Window{
+		X: 20, Y: 20, W: logicalScreenWidth - 40, H: logicalScreenHeight - 40,
+		Title:    "Life Lexicon (type to search, enter or Load to choose)",
+		Font:     mplusNormalFont,
+		Children: []widget.Widget{search, pb.list, pb.preview, loadButton},
+	}
+	return pb
+}
+
+// filter narrows the list to entries whose name or code contains query
+// (case-insensitively), and rebuilds the rows shown in the list widget.
+func (pb *patternBrowser) filter(query string) {
+	pb.query = query
+	query = strings.ToLower(query)
+	pb.filtered = pb.filtered[:0]
+	items := pb.list.Items[:0]
+	for _, e := range pb.entries {
+		if query == "" || strings.Contains(strings.ToLower(e.Name), query) || strings.Contains(strings.ToLower(e.Code), query) {
+			pb.filtered = append(pb.filtered, e)
+			items = append(items, fmt.Sprintf("%s (%s) - %s", e.Name, e.Code, e.Description))
+		}
+	}
+	pb.list.Items = items
+	pb.list.Selected = 0
+	pb.previewCode = ""
+	pb.previewCells = nil
+	pb.startPreview(0)
+}
+
+// setEntries replaces the entries shown in the browser, e.g. once a
+// background lexicon.Refresh() completes, while keeping the current search
+// query applied.
+func (pb *patternBrowser) setEntries(entries []lexicon.Entry) {
+	pb.entries = entries
+	pb.filter(pb.query)
+}
+
+// startPreview kicks off a background fetch of the full pattern for the
+// highlighted entry so paging through the list stays responsive even over a
+// slow connection; the result is picked up in Update.
+func (pb *patternBrowser) startPreview(index int) {
+	if index < 0 || index >= len(pb.filtered) {
+		return
+	}
+	code := pb.filtered[index].Code
+	if code == pb.previewCode {
+		return
+	}
+	pb.previewCode = code
+	pb.previewCells = nil
+	go func() {
+		def, err := fetchLexiconPattern(code)
+		if err != nil {
+			return
+		}
+		width, height, cells := patternCells(def)
+		pb.fetched <- previewResult{code: code, width: width, height: height, cells: cells}
+	}()
+}
+
+func (pb *patternBrowser) Update() {
+	select {
+	case res := <-pb.fetched:
+		if res.code == pb.previewCode {
+			pb.previewW, pb.previewH, pb.previewCells = res.width, res.height, res.cells
+			pb.preview.Cols, pb.preview.Rows = res.width, res.height
+		}
+	default:
+	}
+	pb.window.Update()
+}
+
+func (pb *patternBrowser) Draw(screen *ebiten.Image) {
+	pb.window.Draw(screen)
 }
 
 func (g *Game) patternIndex(x, y int) int {
 	return y*g.patternWidth + x
 }
 
+// worldPointAt returns the (fractional) world coordinates under the given
+// logical screen position.
+func (g *Game) worldPointAt(sx, sy int) (float64, float64) {
+	wx := g.viewport.CenterX + (float64(sx)-logicalScreenWidth/2.0)/g.viewport.CellPixels
+	wy := g.viewport.CenterY + (float64(sy)-logicalScreenHeight/2.0)/g.viewport.CellPixels
+	return wx, wy
+}
+
+// screenToWorld returns the integer cell coordinates under the given
+// logical screen position.
+func (g *Game) screenToWorld(sx, sy int) (int, int) {
+	wx, wy := g.worldPointAt(sx, sy)
+	return int(math.Floor(wx)), int(math.Floor(wy))
+}
+
+// worldToScreen returns the logical screen position of the top-left corner
+// of cell (x, y).
+func (g *Game) worldToScreen(x, y int) (float32, float32) {
+	sx := (float64(x)-g.viewport.CenterX)*g.viewport.CellPixels + logicalScreenWidth/2.0
+	sy := (float64(y)-g.viewport.CenterY)*g.viewport.CellPixels + logicalScreenHeight/2.0
+	return float32(sx), float32(sy)
+}
+
+// wrapToroidal wraps v into [0, size).
+func wrapToroidal(v, size int) int {
+	return ((v % size) + size) % size
+}
+
+// recenterViewport centers the viewport on the bounding box of all living
+// cells, leaving CellPixels (zoom) untouched.
+func (g *Game) recenterViewport() {
+	minX, minY, maxX, maxY, ok := g.world.Bounds()
+	if !ok {
+		return
+	}
+	g.viewport.CenterX = float64(minX+maxX+1) / 2
+	g.viewport.CenterY = float64(minY+maxY+1) / 2
+}
+
 func (g *Game) ManageKeys() {
+	if g.browserVisible {
+		if inpututil.IsKeyJustPressed(ebiten.KeyEscape) {
+			g.browserVisible = false
+		}
+		return
+	}
+
 	if inpututil.IsKeyJustPressed(ebiten.KeyQ) {
 		g.terminated = true
 	}
 
+	if inpututil.IsKeyJustPressed(ebiten.KeyB) {
+		g.browserVisible = true
+	}
+
 	if inpututil.IsKeyJustPressed(ebiten.KeySpace) {
 		if g.welcomeScreen {
 			g.welcomeScreen = false
@@ -81,7 +311,11 @@ func (g *Game) ManageKeys() {
 	}
 
 	if inpututil.IsKeyJustPressed(ebiten.KeyBackspace) {
-		g.initCells()
+		g.initWorld()
+	}
+
+	if inpututil.IsKeyJustPressed(ebiten.KeyHome) {
+		g.recenterViewport()
 	}
 
 	if inpututil.IsKeyJustPressed(ebiten.KeyDelete) {
@@ -114,6 +348,32 @@ func (g *Game) ManageKeys() {
 	if inpututil.IsKeyJustPressed(ebiten.KeyF) {
 		g.showTPS = !g.showTPS
 	}
+
+	if inpututil.IsKeyJustPressed(ebiten.KeyR) {
+		g.ruleIndex = (g.ruleIndex + 1) % len(pattern.BuiltinRulesets)
+		g.rules = pattern.BuiltinRulesets[g.ruleIndex].Rules
+	}
+
+	if inpututil.IsKeyJustPressed(ebiten.KeyT) {
+		g.toroidal = !g.toroidal
+	}
+
+	if inpututil.IsKeyJustPressed(ebiten.KeyG) {
+		for i, mode := range gradientModeOrder {
+			if mode == g.gradient.Mode {
+				g.gradient.Mode = gradientModeOrder[(i+1)%len(gradientModeOrder)]
+				break
+			}
+		}
+	}
+
+	if inpututil.IsKeyJustPressed(ebiten.KeyS) {
+		g.savePatternRLE(rlePatternPath)
+	}
+
+	if inpututil.IsKeyJustPressed(ebiten.KeyL) {
+		g.loadPatternRLE(rlePatternPath)
+	}
 }
 
 func (g *Game) Update() error {
@@ -124,78 +384,185 @@ func (g *Game) Update() error {
 		return ebiten.Termination
 	}
 
-	if g.editorVisible && inpututil.IsMouseButtonJustPressed(ebiten.MouseButtonLeft) {
-		x, y := ebiten.CursorPosition()
-		patternEditorX := logicalScreenWidth - (g.patternWidth * g.patternEditorScale)
-		patternEditorY := g.patternHeight * g.patternEditorScale
-		if x >= patternEditorX && y <= patternEditorY {
-			x = (x - patternEditorX) / g.patternEditorScale
-			y = y / g.patternEditorScale
-			g.pattern[g.patternIndex(x, y)] = !g.pattern[g.patternIndex(x, y)]
-		}
+	select {
+	case entries := <-g.lexiconRefresh:
+		g.browser.setEntries(entries)
+	default:
 	}
 
-	if ebiten.IsMouseButtonPressed(ebiten.MouseButtonLeft) {
-		x, y := ebiten.CursorPosition()
-		patternEditorX := logicalScreenWidth - (g.patternWidth * g.patternEditorScale)
-		patternEditorY := g.patternHeight * g.patternEditorScale
-		if !g.editorVisible || x < patternEditorX || y > patternEditorY {
-			x = x / logicalScreenFactor
-			y = y / logicalScreenFactor
-			for i := 0; i < g.patternHeight; i++ {
-				for j := 0; j < g.patternWidth; j++ {
-					g.cells[g.index(x+j-g.patternWidth, y+i-g.patternHeight)] = g.pattern[g.patternIndex(j, i)]
+	if g.browserVisible {
+		g.root.Update()
+	} else {
+		if g.editorVisible && inpututil.IsMouseButtonJustPressed(ebiten.MouseButtonLeft) {
+			x, y := ebiten.CursorPosition()
+			patternEditorX := logicalScreenWidth - (g.patternWidth * g.patternEditorScale)
+			patternEditorY := g.patternHeight * g.patternEditorScale
+			if x >= patternEditorX && y <= patternEditorY {
+				x = (x - patternEditorX) / g.patternEditorScale
+				y = y / g.patternEditorScale
+				g.pattern[g.patternIndex(x, y)] = !g.pattern[g.patternIndex(x, y)]
+			}
+		}
+
+		if ebiten.IsMouseButtonPressed(ebiten.MouseButtonLeft) {
+			x, y := ebiten.CursorPosition()
+			patternEditorX := logicalScreenWidth - (g.patternWidth * g.patternEditorScale)
+			patternEditorY := g.patternHeight * g.patternEditorScale
+			if !g.editorVisible || x < patternEditorX || y > patternEditorY {
+				wx, wy := g.screenToWorld(x/logicalScreenFactor, y/logicalScreenFactor)
+				for i := 0; i < g.patternHeight; i++ {
+					for j := 0; j < g.patternWidth; j++ {
+						age := uint16(0)
+						if g.pattern[g.patternIndex(j, i)] {
+							age = 1
+						}
+						cx, cy := wx+j-g.patternWidth, wy+i-g.patternHeight
+						if g.toroidal {
+							cx = wrapToroidal(cx, logicalScreenWidth)
+							cy = wrapToroidal(cy, logicalScreenHeight)
+						}
+						g.world.Set(cx, cy, age)
+					}
 				}
+				// Stamping can zero out a chunk's last live cell; step()
+				// rebuilds the world from scratch every tick and so drops
+				// empty chunks on its own, but while paused Set leaves the
+				// now-dead chunk allocated until something prunes it.
+				g.world.Prune()
 			}
 		}
+
+		g.manageViewport()
 	}
 
 	if g.active && timeDelta >= g.speed*time.Millisecond {
 		g.lastUpdateTime = time.Now()
+		g.step()
+	}
+	return nil
+}
 
-		// Rules of life:
-		// 1. Any live cell with fewer than two live neighbours dies, as if by underpopulation.
-		// 2. Any live cell with two or three live neighbours lives on to the next generation.
-		// 3. Any live cell with more than three live neighbours dies, as if by overpopulation.
-		// 4. Any dead cell with exactly three live neighbours becomes a live cell, as if by reproduction.
-
-		// Make a copy of the cells
-		cellsCopy := make([]bool, logicalScreenHeight*logicalScreenWidth)
-
-		for i := 0; i < logicalScreenHeight; i++ {
-			for j := 0; j < logicalScreenWidth; j++ {
-				// Count the number of live neighbors
-				neighbors := 0
-				for y := i - 1; y <= i+1; y++ {
-					for x := j - 1; x <= j+1; x++ {
-						if x >= 0 && x < logicalScreenWidth && y >= 0 && y < logicalScreenHeight && !(x == j && y == i) {
-							if g.cells[g.index(x, y)] {
-								neighbors++
-							}
-						}
-					}
+// manageViewport handles mouse-wheel zoom (anchored at the cursor) and
+// middle-drag panning.
+func (g *Game) manageViewport() {
+	if _, wheelY := ebiten.Wheel(); wheelY != 0 {
+		cx, cy := ebiten.CursorPosition()
+		beforeX, beforeY := g.worldPointAt(cx/logicalScreenFactor, cy/logicalScreenFactor)
+
+		g.viewport.CellPixels *= math.Pow(zoomStep, wheelY)
+		if g.viewport.CellPixels < minCellPixels {
+			g.viewport.CellPixels = minCellPixels
+		} else if g.viewport.CellPixels > maxCellPixels {
+			g.viewport.CellPixels = maxCellPixels
+		}
+
+		afterX, afterY := g.worldPointAt(cx/logicalScreenFactor, cy/logicalScreenFactor)
+		g.viewport.CenterX += beforeX - afterX
+		g.viewport.CenterY += beforeY - afterY
+	}
+
+	if inpututil.IsMouseButtonJustPressed(ebiten.MouseButtonMiddle) {
+		g.panning = true
+		g.panLastX, g.panLastY = ebiten.CursorPosition()
+	} else if inpututil.IsMouseButtonJustReleased(ebiten.MouseButtonMiddle) {
+		g.panning = false
+	}
+
+	if g.panning {
+		cx, cy := ebiten.CursorPosition()
+		dx := (cx - g.panLastX) / logicalScreenFactor
+		dy := (cy - g.panLastY) / logicalScreenFactor
+		g.viewport.CenterX -= float64(dx) / g.viewport.CellPixels
+		g.viewport.CenterY -= float64(dy) / g.viewport.CellPixels
+		g.panLastX, g.panLastY = cx, cy
+	}
+}
+
+// step advances the world by one generation. The standard Conway ruleset
+// takes the bitpacked fast path as long as toroidal wrap is off, since its
+// half-adder kernel treats cells beyond the world's edge as dead rather
+// than wrapping; any other case falls back to the scalar scan.
+//
+// Both paths build a fresh *world.World each tick rather than double-
+// buffering two fixed slabs: the world is a sparse map keyed by chunk, and
+// its active region grows and shrinks as patterns spread or die out, so
+// there's no fixed-size slab to swap into. The per-tick allocation is
+// bounded by ActiveChunks(), not the whole world, and naturally drops any
+// chunk with no live cells; see world.Prune for the one path (pausing mid-
+// edit) where a dead chunk can otherwise linger.
+func (g *Game) step() {
+	if !g.toroidal && g.rules == pattern.Conway {
+		g.world = g.world.StepConwayFast()
+		return
+	}
+	g.world = g.stepScalar()
+}
+
+// stepScalar advances the world by one generation using a plain per-cell
+// neighbor scan. It supports arbitrary Life-like rulesets and, when
+// g.toroidal is set, wraps around the logicalScreenWidth x
+// logicalScreenHeight extent instead of treating the world as unbounded.
+//
+// Off the torus, only chunks ActiveChunks() considers live (or adjacent to
+// live) are scanned, since anything farther out is dead and can't change.
+// On the torus that shortcut breaks: a cell just past one edge is adjacent,
+// by wraparound, to one just past the opposite edge, so a chunk ActiveChunks
+// would skip can still receive a birth. Toroidal mode therefore scans every
+// cell in the fixed extent directly; every cell it touches is already
+// in-bounds, so births need no separate wrap-on-write step.
+func (g *Game) stepScalar() *world.World {
+	next := world.New()
+	evolve := func(x, y int) {
+		neighbors := 0
+		for dy := -1; dy <= 1; dy++ {
+			for dx := -1; dx <= 1; dx++ {
+				if dx == 0 && dy == 0 {
+					continue
 				}
-				// Apply the rules of life
-				index := g.index(j, i)
-				if g.cells[index] {
-					if neighbors < 2 || neighbors > 3 {
-						cellsCopy[index] = false
-					} else {
-						cellsCopy[index] = true
-					}
-				} else {
-					if neighbors == 3 {
-						cellsCopy[index] = true
-					} else {
-						cellsCopy[index] = false
-					}
+				nx, ny := x+dx, y+dy
+				if g.toroidal {
+					nx = wrapToroidal(nx, logicalScreenWidth)
+					ny = wrapToroidal(ny, logicalScreenHeight)
+				}
+				if g.world.Get(nx, ny) > 0 {
+					neighbors++
 				}
 			}
 		}
-		// Copy the cells back
-		g.cells = cellsCopy
+
+		age := g.world.Get(x, y)
+		alive := age > 0
+		var born bool
+		if alive {
+			born = g.rules.Survival[neighbors]
+		} else {
+			born = g.rules.Birth[neighbors]
+		}
+		switch {
+		case born && alive:
+			next.Set(x, y, world.NextAge(age))
+		case born:
+			next.Set(x, y, 1)
+		}
 	}
-	return nil
+
+	if g.toroidal {
+		for y := 0; y < logicalScreenHeight; y++ {
+			for x := 0; x < logicalScreenWidth; x++ {
+				evolve(x, y)
+			}
+		}
+		return next
+	}
+
+	for _, coord := range g.world.ActiveChunks() {
+		for ly := 0; ly < world.ChunkSize; ly++ {
+			for lx := 0; lx < world.ChunkSize; lx++ {
+				evolve(coord.X*world.ChunkSize+lx, coord.Y*world.ChunkSize+ly)
+			}
+		}
+	}
+	return next
 }
 
 func (g *Game) Draw(screen *ebiten.Image) {
@@ -212,6 +579,13 @@ func (g *Game) Draw(screen *ebiten.Image) {
 			"Press <up>/<down>/<left>/<right> to change the pattern size\n"+
 			"Press <+>/<-> to change the speed\n"+
 			"Press <f> to toggle the TPS (ticks per second) display\n"+
+			"Press <r> to cycle through built-in rulesets\n"+
+			"Press <t> to toggle toroidal (wrap-around) mode\n"+
+			"Press <g> to cycle the cell-age gradient mode\n"+
+			"Mouse wheel to zoom, middle-drag to pan, <home> to recenter\n"+
+			"Press <s> to save the current pattern as RLE\n"+
+			"Press <l> to load a pattern from RLE\n"+
+			"Press <b> to browse the Life Lexicon, <esc> to close it\n"+
 			"Press <q> to quit\n",
 			20, 20,
 		)
@@ -219,13 +593,38 @@ func (g *Game) Draw(screen *ebiten.Image) {
 		return
 	}
 
-	// Draw a pixel at each cell that is alive
-	for i := 0; i < logicalScreenHeight; i++ {
-		for j := 0; j < logicalScreenWidth; j++ {
-			if g.cells[g.index(j, i)] {
-				x := float32(j * logicalScreenFactor)
-				y := float32(i * logicalScreenFactor)
-				vector.DrawFilledRect(screen, x, y, logicalScreenFactor, logicalScreenFactor, color.White, false)
+	// Draw every live cell in the chunks intersecting the viewport, colored
+	// by its age along the active gradient (newborn cells at one end,
+	// older cells at the other)
+	minX, minY := g.screenToWorld(0, 0)
+	maxX, maxY := g.screenToWorld(logicalScreenWidth, logicalScreenHeight)
+	minChunkX, minChunkY := minX/world.ChunkSize-1, minY/world.ChunkSize-1
+	maxChunkX, maxChunkY := maxX/world.ChunkSize+1, maxY/world.ChunkSize+1
+
+	cellSize := float32(g.viewport.CellPixels)
+	if cellSize < 1 {
+		cellSize = 1
+	}
+	for cy := minChunkY; cy <= maxChunkY; cy++ {
+		for cx := minChunkX; cx <= maxChunkX; cx++ {
+			chunk, ok := g.world.Chunk(world.ChunkCoord{X: cx, Y: cy})
+			if !ok {
+				continue
+			}
+			for ly := 0; ly < world.ChunkSize; ly++ {
+				for lx := 0; lx < world.ChunkSize; lx++ {
+					age := chunk.Cells[ly*world.ChunkSize+lx]
+					if age == 0 {
+						continue
+					}
+					x, y := g.worldToScreen(cx*world.ChunkSize+lx, cy*world.ChunkSize+ly)
+					t := age
+					if t > g.ageCap {
+						t = g.ageCap
+					}
+					c := g.gradient.At(float64(t) / float64(g.ageCap))
+					vector.DrawFilledRect(screen, x, y, cellSize, cellSize, c, false)
+				}
 			}
 		}
 	}
@@ -259,19 +658,25 @@ func (g *Game) Draw(screen *ebiten.Image) {
 		vector.DrawFilledRect(screen, logicalScreenWidth-40, logicalScreenHeight-80, 10, 40, color.White, false)
 	}
 
-	// Display TPS in the top left corner
+	// Display TPS and the active rule in the top left corner
 	if g.showTPS {
-		msg := fmt.Sprintf("TPS: %0.2f", ebiten.ActualTPS())
+		msg := fmt.Sprintf("TPS: %0.2f  Rule: %s", ebiten.ActualTPS(), g.rules.String())
 		text.Draw(screen, msg, mplusNormalFont, 10, 10, color.White)
 	}
+
+	if g.browserVisible {
+		g.root.Draw(screen)
+	}
 }
 
 func (g *Game) Layout(outsideWidth, outsideHeight int) (screenWidth, screenHeight int) {
 	return logicalScreenWidth, logicalScreenHeight
 }
 
-func (g *Game) initCells() {
-	g.cells = make([]bool, logicalScreenHeight*logicalScreenWidth)
+// initWorld resets the simulation to an empty world, keeping the current
+// viewport position and zoom.
+func (g *Game) initWorld() {
+	g.world = world.New()
 }
 
 func (g *Game) initPattern(newWidth int, newHeight int, keepPrevious bool) {
@@ -323,39 +728,128 @@ func (g *Game) initGlider() {
 	g.pattern[g.patternIndex(2, 2)] = true
 }
 
-func (g *Game) initPatternFromUrl(url string) {
-	client := http.Client{}
-	res, err := client.Get(fmt.Sprintf("https://playgameoflife.com/lexicon/data/%s.json", url))
+// fetchLexiconPattern retrieves and parses the plaintext pattern JSON for
+// the given Life Lexicon code, e.g. from a click in the pattern browser or a
+// code passed on the command line.
+func fetchLexiconPattern(code string) (*PatternDefinition, error) {
+	res, err := http.Get(fmt.Sprintf("https://playgameoflife.com/lexicon/data/%s.json", code))
 	if err != nil {
-		log.Fatal(err)
-	}
-	if res.Body != nil {
-		defer res.Body.Close()
+		return nil, err
 	}
-	body, readErr := io.ReadAll(res.Body)
-	if readErr != nil {
-		log.Fatal(readErr)
+	defer res.Body.Close()
+	body, err := io.ReadAll(res.Body)
+	if err != nil {
+		return nil, err
 	}
-	definition := PatternDefinition{}
-	jsonErr := json.Unmarshal(body, &definition)
-	if jsonErr != nil {
-		log.Fatal(jsonErr)
+	var definition PatternDefinition
+	if err := json.Unmarshal(body, &definition); err != nil {
+		return nil, err
 	}
+	return &definition, nil
+}
 
-	patternLines := strings.Split(definition.Pattern, "\n")
-	if (patternLines[len(patternLines)-1]) == "" {
-		patternLines = patternLines[:len(patternLines)-1]
+// patternCells decodes def.Pattern's plaintext grid ('O' for alive) into a
+// width x height cell slice.
+func patternCells(def *PatternDefinition) (width, height int, cells []bool) {
+	lines := strings.Split(def.Pattern, "\n")
+	if len(lines) > 0 && lines[len(lines)-1] == "" {
+		lines = lines[:len(lines)-1]
 	}
-	g.patternWidth = len(patternLines[0])
-	g.patternHeight = len(patternLines)
-	g.initPattern(g.patternWidth, g.patternHeight, false)
-	for i, line := range patternLines {
+	if len(lines) == 0 {
+		return 0, 0, nil
+	}
+	width = len(lines[0])
+	height = len(lines)
+	cells = make([]bool, width*height)
+	for i, line := range lines {
 		for j, char := range line {
-			if char == 'O' {
-				g.pattern[g.patternIndex(j, i)] = true
+			if char == 'O' && j < width {
+				cells[i*width+j] = true
 			}
 		}
 	}
+	return width, height, cells
+}
+
+// initPatternFromUrl fetches the Life Lexicon pattern for code and installs
+// it as the current editor pattern.
+func (g *Game) initPatternFromUrl(code string) error {
+	def, err := fetchLexiconPattern(code)
+	if err != nil {
+		return err
+	}
+	width, height, cells := patternCells(def)
+	if width == 0 || height == 0 {
+		return fmt.Errorf("pattern: %q has no cells", code)
+	}
+	g.initPattern(width, height, false)
+	copy(g.pattern, cells)
+	return nil
+}
+
+// initPatternFromRLE loads a pattern in RLE format from a local path or an
+// http(s) URL and installs it as the current editor pattern.
+func (g *Game) initPatternFromRLE(source string) {
+	var r io.Reader
+	if strings.HasPrefix(source, "http://") || strings.HasPrefix(source, "https://") {
+		res, err := http.Get(source)
+		if err != nil {
+			log.Fatal(err)
+		}
+		defer res.Body.Close()
+		r = res.Body
+	} else {
+		f, err := os.Open(source)
+		if err != nil {
+			log.Fatal(err)
+		}
+		defer f.Close()
+		r = f
+	}
+
+	def, err := pattern.ParseRLE(r)
+	if err != nil {
+		log.Fatal(err)
+	}
+	g.initPattern(def.Width, def.Height, false)
+	copy(g.pattern, def.Cells)
+	if def.HasRules {
+		g.rules = def.Rules
+	}
+}
+
+// savePatternRLE writes the current editor pattern to path in RLE format.
+func (g *Game) savePatternRLE(path string) {
+	f, err := os.Create(path)
+	if err != nil {
+		log.Println("pattern: save failed:", err)
+		return
+	}
+	defer f.Close()
+	if err := pattern.WriteRLE(f, g.patternWidth, g.patternHeight, g.pattern, g.rules); err != nil {
+		log.Println("pattern: save failed:", err)
+	}
+}
+
+// loadPatternRLE reads path as RLE and installs it as the current editor
+// pattern.
+func (g *Game) loadPatternRLE(path string) {
+	f, err := os.Open(path)
+	if err != nil {
+		log.Println("pattern: load failed:", err)
+		return
+	}
+	defer f.Close()
+	def, err := pattern.ParseRLE(f)
+	if err != nil {
+		log.Println("pattern: load failed:", err)
+		return
+	}
+	g.initPattern(def.Width, def.Height, false)
+	copy(g.pattern, def.Cells)
+	if def.HasRules {
+		g.rules = def.Rules
+	}
 }
 
 func loadFont() {
@@ -375,22 +869,76 @@ func loadFont() {
 }
 
 func main() {
+	ruleFlag := flag.String("rule", "", "Life-like rule in B/S notation, e.g. B3/S23 (default Conway)")
+	gradientFlag := flag.String("gradient", defaultGradientMode, "cell-age gradient mode: linear, poly or bezier")
+	colorsFlag := flag.String("colors", defaultColors, "comma-separated #rrggbb gradient stops, newborn to oldest")
+	flag.Parse()
+
 	loadFont()
 	g := &Game{
 		active:         false,
+		ageCap:         defaultAgeCap,
 		editorVisible:  true,
 		lastUpdateTime: time.Now(),
 		patternHeight:  3,
 		patternWidth:   3,
+		rules:          pattern.Conway,
 		showTPS:        false,
 		speed:          25,
+		viewport:       Viewport{CellPixels: defaultCellPixels},
 		welcomeScreen:  true,
 	}
-	g.initCells()
+	g.initWorld()
+
+	entries, err := lexicon.Load()
+	if err != nil {
+		log.Fatal(err)
+	}
+	g.browser = newPatternBrowser(entries, func(code string) {
+		if err := g.initPatternFromUrl(code); err != nil {
+			log.Println("lexicon: load failed:", err)
+			return
+		}
+		g.browserVisible = false
+	})
+	g.root = &widget.Root{Children: []widget.Widget{g.browser}}
+
+	// Refresh the bundled/cached lexicon index from the network in the
+	// background; Update() picks up the result without blocking startup or
+	// risking stale widget state from another goroutine.
+	g.lexiconRefresh = make(chan []lexicon.Entry, 1)
+	go func() {
+		if fresh, err := lexicon.Refresh(); err == nil {
+			g.lexiconRefresh <- fresh
+		}
+	}()
+
+	if *ruleFlag != "" {
+		rules, err := pattern.ParseRules(*ruleFlag)
+		if err != nil {
+			log.Fatal(err)
+		}
+		g.rules = rules
+	}
 
-	// If a command-line argument is passed
-	if len(os.Args) > 1 {
-		g.initPatternFromUrl(os.Args[1])
+	mode, err := gradient.ParseMode(*gradientFlag)
+	if err != nil {
+		log.Fatal(err)
+	}
+	stops, err := gradient.ParseStops(*colorsFlag)
+	if err != nil {
+		log.Fatal(err)
+	}
+	g.gradient = gradient.Gradient{Mode: mode, Stops: stops}
+
+	// If a positional argument is passed, load it as the starting pattern
+	if flag.NArg() > 0 {
+		arg := flag.Arg(0)
+		if strings.HasSuffix(arg, ".rle") {
+			g.initPatternFromRLE(arg)
+		} else if err := g.initPatternFromUrl(arg); err != nil {
+			log.Fatal(err)
+		}
 	} else {
 		g.initGlider()
 	}
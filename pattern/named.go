@@ -0,0 +1,25 @@
+package pattern
+
+// NamedRuleset pairs a human-readable ruleset name with its Rules.
+type NamedRuleset struct {
+	Name  string
+	Rules Rules
+}
+
+// BuiltinRulesets are well-known Life-like automata, offered for cycling
+// through via an in-game hotkey.
+var BuiltinRulesets = []NamedRuleset{
+	{Name: "Conway", Rules: Conway},
+	{Name: "HighLife", Rules: mustParseRules("B36/S23")},
+	{Name: "Seeds", Rules: mustParseRules("B2/S")},
+	{Name: "Day & Night", Rules: mustParseRules("B3678/S34678")},
+	{Name: "Life without Death", Rules: mustParseRules("B3/S012345678")},
+}
+
+func mustParseRules(s string) Rules {
+	r, err := ParseRules(s)
+	if err != nil {
+		panic(err)
+	}
+	return r
+}
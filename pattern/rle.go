@@ -0,0 +1,211 @@
+package pattern
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// PatternDefinition is a decoded pattern together with the metadata carried
+// by its source format: a name, a description and an optional custom rule.
+type PatternDefinition struct {
+	Name        string
+	Description string
+	Width       int
+	Height      int
+	Cells       []bool
+	Rules       Rules
+	HasRules    bool
+}
+
+func (d *PatternDefinition) index(x, y int) int {
+	return y*d.Width + x
+}
+
+var headerRe = regexp.MustCompile(`x\s*=\s*(\d+)\s*,\s*y\s*=\s*(\d+)(?:\s*,\s*rule\s*=\s*(.*))?`)
+
+// ParseRLE decodes a pattern in the standard Run-Length Encoded format used
+// by LifeWiki and the Life Lexicon.
+func ParseRLE(r io.Reader) (*PatternDefinition, error) {
+	def := &PatternDefinition{}
+	scanner := bufio.NewScanner(r)
+
+	var header string
+	for header == "" && scanner.Scan() {
+		line := scanner.Text()
+		switch {
+		case strings.HasPrefix(line, "#N"):
+			def.Name = strings.TrimSpace(strings.TrimPrefix(line, "#N"))
+		case strings.HasPrefix(line, "#C"):
+			desc := strings.TrimSpace(strings.TrimPrefix(line, "#C"))
+			if def.Description == "" {
+				def.Description = desc
+			} else {
+				def.Description += "\n" + desc
+			}
+		case strings.HasPrefix(line, "#"):
+			// Other comment tags (#O, #P, ...) carry no fields we track.
+		default:
+			header = line
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+	if header == "" {
+		return nil, fmt.Errorf("pattern: missing RLE header line")
+	}
+
+	m := headerRe.FindStringSubmatch(header)
+	if m == nil {
+		return nil, fmt.Errorf("pattern: malformed RLE header %q", header)
+	}
+	width, err := strconv.Atoi(m[1])
+	if err != nil {
+		return nil, fmt.Errorf("pattern: invalid width in header: %w", err)
+	}
+	height, err := strconv.Atoi(m[2])
+	if err != nil {
+		return nil, fmt.Errorf("pattern: invalid height in header: %w", err)
+	}
+	def.Width = width
+	def.Height = height
+	def.Cells = make([]bool, width*height)
+
+	if rule := strings.TrimSpace(m[3]); rule != "" {
+		rules, err := ParseRules(rule)
+		if err != nil {
+			return nil, err
+		}
+		def.Rules = rules
+		def.HasRules = true
+	}
+
+	var body strings.Builder
+	for scanner.Scan() {
+		body.WriteString(strings.TrimSpace(scanner.Text()))
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+	if err := decodeRLEBody(body.String(), def); err != nil {
+		return nil, err
+	}
+	return def, nil
+}
+
+// decodeRLEBody walks the run/tag tokens of an RLE body: an optional
+// run-count (defaulting to 1) followed by 'b' (dead), 'o' (alive), '$'
+// (end of row) or '!' (end of pattern). Rows shorter than the declared
+// width are left padded with dead cells.
+func decodeRLEBody(body string, def *PatternDefinition) error {
+	x, y := 0, 0
+	runLen := 0
+	for _, c := range body {
+		if c >= '0' && c <= '9' {
+			runLen = runLen*10 + int(c-'0')
+			continue
+		}
+		n := runLen
+		if n == 0 {
+			n = 1
+		}
+		runLen = 0
+		switch c {
+		case 'b':
+			x += n
+		case 'o':
+			for i := 0; i < n; i++ {
+				if x < def.Width && y < def.Height {
+					def.Cells[def.index(x, y)] = true
+				}
+				x++
+			}
+		case '$':
+			y += n
+			x = 0
+		case '!':
+			return nil
+		default:
+			return fmt.Errorf("pattern: unexpected RLE token %q", c)
+		}
+	}
+	return fmt.Errorf("pattern: RLE body missing terminating '!'")
+}
+
+// WriteRLE encodes a width x height board (row-major, true = alive) as RLE,
+// wrapping output lines at 70 characters as is conventional.
+func WriteRLE(w io.Writer, width, height int, cells []bool, rules Rules) error {
+	if _, err := fmt.Fprintf(w, "x = %d, y = %d, rule = %s\n", width, height, rules.String()); err != nil {
+		return err
+	}
+
+	var line strings.Builder
+	flush := func(tok string) error {
+		if line.Len()+len(tok) > 70 {
+			if _, err := io.WriteString(w, line.String()+"\n"); err != nil {
+				return err
+			}
+			line.Reset()
+		}
+		line.WriteString(tok)
+		return nil
+	}
+
+	for y := 0; y < height; y++ {
+		runs := rowRuns(cells[y*width : (y+1)*width])
+		if len(runs) > 0 && !runs[len(runs)-1].alive {
+			// Trailing dead cells before the end of a row are implied.
+			runs = runs[:len(runs)-1]
+		}
+		for _, run := range runs {
+			if err := flush(rleToken(run.n, run.alive)); err != nil {
+				return err
+			}
+		}
+		eol := "$"
+		if y == height-1 {
+			eol = "!"
+		}
+		if err := flush(eol); err != nil {
+			return err
+		}
+	}
+	if line.Len() > 0 {
+		if _, err := io.WriteString(w, line.String()+"\n"); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+type rleRun struct {
+	n     int
+	alive bool
+}
+
+func rowRuns(row []bool) []rleRun {
+	var runs []rleRun
+	for _, v := range row {
+		if len(runs) > 0 && runs[len(runs)-1].alive == v {
+			runs[len(runs)-1].n++
+		} else {
+			runs = append(runs, rleRun{n: 1, alive: v})
+		}
+	}
+	return runs
+}
+
+func rleToken(n int, alive bool) string {
+	tag := "b"
+	if alive {
+		tag = "o"
+	}
+	if n == 1 {
+		return tag
+	}
+	return strconv.Itoa(n) + tag
+}
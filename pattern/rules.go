@@ -0,0 +1,61 @@
+// Package pattern implements parsing and serialization of Game of Life
+// patterns, including the plaintext format served by playgameoflife.com
+// and the standard RLE (Run-Length Encoded) format.
+package pattern
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// Rules describes a Life-like cellular automaton in B/S (birth/survival)
+// notation: a dead cell is born if Birth[neighbors] is true, and a live
+// cell survives if Survival[neighbors] is true.
+type Rules struct {
+	Birth    [9]bool
+	Survival [9]bool
+}
+
+// Conway is the standard B3/S23 ruleset.
+var Conway = Rules{
+	Birth:    [9]bool{3: true},
+	Survival: [9]bool{2: true, 3: true},
+}
+
+var rulesRe = regexp.MustCompile(`^[Bb]([0-8]*)/[Ss]([0-8]*)$`)
+
+// ParseRules parses Life-like rule strings in B/S notation, e.g. "B3/S23"
+// (Conway), "B36/S23" (HighLife), "B2/S" (Seeds) or "B3/S012345678" (Life
+// without death).
+func ParseRules(s string) (Rules, error) {
+	m := rulesRe.FindStringSubmatch(strings.TrimSpace(s))
+	if m == nil {
+		return Rules{}, fmt.Errorf("pattern: invalid rule string %q", s)
+	}
+	var r Rules
+	for _, c := range m[1] {
+		r.Birth[c-'0'] = true
+	}
+	for _, c := range m[2] {
+		r.Survival[c-'0'] = true
+	}
+	return r, nil
+}
+
+// String renders r back to B/S notation.
+func (r Rules) String() string {
+	var b, s strings.Builder
+	b.WriteByte('B')
+	s.WriteByte('S')
+	for n := 0; n <= 8; n++ {
+		if r.Birth[n] {
+			b.WriteString(strconv.Itoa(n))
+		}
+		if r.Survival[n] {
+			s.WriteString(strconv.Itoa(n))
+		}
+	}
+	return b.String() + "/" + s.String()
+}
@@ -0,0 +1,45 @@
+package widget
+
+import (
+	"image/color"
+
+	"github.com/hajimehoshi/ebiten/v2"
+	"github.com/hajimehoshi/ebiten/v2/inpututil"
+	"github.com/hajimehoshi/ebiten/v2/text"
+	"github.com/hajimehoshi/ebiten/v2/vector"
+	"golang.org/x/image/font"
+)
+
+// Button is a clickable rectangular label.
+type Button struct {
+	X, Y, W, H float32
+	Label      string
+	Font       font.Face
+	OnClick    func()
+
+	hovered bool
+}
+
+func (b *Button) contains(x, y int) bool {
+	return float32(x) >= b.X && float32(x) < b.X+b.W && float32(y) >= b.Y && float32(y) < b.Y+b.H
+}
+
+func (b *Button) Update() {
+	x, y := ebiten.CursorPosition()
+	b.hovered = b.contains(x, y)
+	if b.hovered && inpututil.IsMouseButtonJustPressed(ebiten.MouseButtonLeft) && b.OnClick != nil {
+		b.OnClick()
+	}
+}
+
+func (b *Button) Draw(screen *ebiten.Image) {
+	bg := color.RGBA{60, 60, 60, 255}
+	if b.hovered {
+		bg = color.RGBA{90, 90, 90, 255}
+	}
+	vector.DrawFilledRect(screen, b.X, b.Y, b.W, b.H, bg, false)
+	vector.StrokeRect(screen, b.X, b.Y, b.W, b.H, 1, color.RGBA{150, 150, 150, 255}, false)
+	if b.Font != nil {
+		text.Draw(screen, b.Label, b.Font, int(b.X)+6, int(b.Y+b.H)-6, color.White)
+	}
+}
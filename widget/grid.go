@@ -0,0 +1,33 @@
+package widget
+
+import (
+	"image/color"
+
+	"github.com/hajimehoshi/ebiten/v2"
+	"github.com/hajimehoshi/ebiten/v2/vector"
+)
+
+// Grid previews a rectangular boolean grid, one filled square per live
+// cell. It has no input handling of its own; callers drive what it shows by
+// swapping out Alive (and Cols/Rows) whenever the thing being previewed
+// changes, e.g. while paging through a list of candidates.
+type Grid struct {
+	X, Y, CellSize float32
+	Cols, Rows     int
+	Alive          func(x, y int) bool
+}
+
+func (g *Grid) Update() {}
+
+func (g *Grid) Draw(screen *ebiten.Image) {
+	if g.Alive == nil {
+		return
+	}
+	for y := 0; y < g.Rows; y++ {
+		for x := 0; x < g.Cols; x++ {
+			if g.Alive(x, y) {
+				vector.DrawFilledRect(screen, g.X+float32(x)*g.CellSize, g.Y+float32(y)*g.CellSize, g.CellSize, g.CellSize, color.White, false)
+			}
+		}
+	}
+}
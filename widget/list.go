@@ -0,0 +1,99 @@
+package widget
+
+import (
+	"image/color"
+
+	"github.com/hajimehoshi/ebiten/v2"
+	"github.com/hajimehoshi/ebiten/v2/inpututil"
+	"github.com/hajimehoshi/ebiten/v2/text"
+	"github.com/hajimehoshi/ebiten/v2/vector"
+	"golang.org/x/image/font"
+)
+
+// List is a scrollable, single-selection list of strings. Clicking a row or
+// pressing up/down moves the selection and calls OnSelect; pressing enter
+// calls OnConfirm on the current selection. Splitting the two lets a caller
+// preview the highlighted row via OnSelect and only commit to it on
+// OnConfirm.
+type List struct {
+	X, Y, W, H float32
+	RowHeight  float32
+	Font       font.Face
+	Items      []string
+	Selected   int
+	OnSelect   func(index int)
+	OnConfirm  func(index int)
+
+	scroll int
+}
+
+func (l *List) visibleRows() int {
+	if l.RowHeight <= 0 {
+		return 0
+	}
+	return int(l.H / l.RowHeight)
+}
+
+func (l *List) clampScroll() {
+	maxScroll := len(l.Items) - l.visibleRows()
+	if maxScroll < 0 {
+		maxScroll = 0
+	}
+	if l.scroll < 0 {
+		l.scroll = 0
+	} else if l.scroll > maxScroll {
+		l.scroll = maxScroll
+	}
+}
+
+func (l *List) Update() {
+	if _, wheelY := ebiten.Wheel(); wheelY != 0 {
+		l.scroll -= int(wheelY)
+	}
+	l.clampScroll()
+	if len(l.Items) == 0 {
+		return
+	}
+
+	if inpututil.IsMouseButtonJustPressed(ebiten.MouseButtonLeft) {
+		x, y := ebiten.CursorPosition()
+		if float32(x) >= l.X && float32(x) < l.X+l.W && float32(y) >= l.Y && float32(y) < l.Y+l.H {
+			row := l.scroll + int((float32(y)-l.Y)/l.RowHeight)
+			if row >= 0 && row < len(l.Items) {
+				l.selectRow(row)
+			}
+		}
+	}
+
+	if inpututil.IsKeyJustPressed(ebiten.KeyUp) {
+		l.selectRow(max(l.Selected-1, 0))
+	} else if inpututil.IsKeyJustPressed(ebiten.KeyDown) {
+		l.selectRow(min(l.Selected+1, len(l.Items)-1))
+	}
+
+	if inpututil.IsKeyJustPressed(ebiten.KeyEnter) && l.OnConfirm != nil {
+		l.OnConfirm(l.Selected)
+	}
+}
+
+func (l *List) selectRow(row int) {
+	l.Selected = row
+	if l.OnSelect != nil {
+		l.OnSelect(row)
+	}
+}
+
+func (l *List) Draw(screen *ebiten.Image) {
+	vector.DrawFilledRect(screen, l.X, l.Y, l.W, l.H, color.RGBA{20, 20, 20, 230}, false)
+	rows := l.visibleRows()
+	for i := 0; i < rows && l.scroll+i < len(l.Items); i++ {
+		idx := l.scroll + i
+		rowY := l.Y + float32(i)*l.RowHeight
+		if idx == l.Selected {
+			vector.DrawFilledRect(screen, l.X, rowY, l.W, l.RowHeight, color.RGBA{60, 90, 140, 255}, false)
+		}
+		if l.Font != nil {
+			text.Draw(screen, l.Items[idx], l.Font, int(l.X)+4, int(rowY+l.RowHeight)-4, color.White)
+		}
+	}
+}
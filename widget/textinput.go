@@ -0,0 +1,44 @@
+package widget
+
+import (
+	"image/color"
+
+	"github.com/hajimehoshi/ebiten/v2"
+	"github.com/hajimehoshi/ebiten/v2/inpututil"
+	"github.com/hajimehoshi/ebiten/v2/text"
+	"github.com/hajimehoshi/ebiten/v2/vector"
+	"golang.org/x/image/font"
+)
+
+// TextInput is a single-line editable text field. It is always focused
+// while part of a visible tree, since overlays in this game only ever show
+// one at a time.
+type TextInput struct {
+	X, Y, W, H float32
+	Font       font.Face
+	Value      string
+	OnChange   func(string)
+}
+
+func (t *TextInput) Update() {
+	chars := ebiten.AppendInputChars(nil)
+	backspace := inpututil.IsKeyJustPressed(ebiten.KeyBackspace)
+	if len(chars) == 0 && !backspace {
+		return
+	}
+	t.Value += string(chars)
+	if backspace && len(t.Value) > 0 {
+		t.Value = t.Value[:len(t.Value)-1]
+	}
+	if t.OnChange != nil {
+		t.OnChange(t.Value)
+	}
+}
+
+func (t *TextInput) Draw(screen *ebiten.Image) {
+	vector.DrawFilledRect(screen, t.X, t.Y, t.W, t.H, color.RGBA{20, 20, 20, 255}, false)
+	vector.StrokeRect(screen, t.X, t.Y, t.W, t.H, 1, color.RGBA{150, 150, 150, 255}, false)
+	if t.Font != nil {
+		text.Draw(screen, t.Value, t.Font, int(t.X)+4, int(t.Y+t.H)-6, color.White)
+	}
+}
@@ -0,0 +1,34 @@
+// Package widget implements a small widget tree for in-game overlays:
+// buttons, a scrollable list, a text input and a modal window, composed
+// under a Root that dispatches per-frame input and draw calls to its
+// children. It follows the usual Ebitengine toolkit shape (a root widget
+// handling mouse/keyboard events and deferring drawing to its children)
+// without pulling in a full UI library.
+package widget
+
+import "github.com/hajimehoshi/ebiten/v2"
+
+// Widget is a node in a widget tree: it can handle per-frame input and
+// draw itself.
+type Widget interface {
+	Update()
+	Draw(screen *ebiten.Image)
+}
+
+// Root is the entry point for an overlay's widget tree: it dispatches
+// Update and Draw to an ordered set of children.
+type Root struct {
+	Children []Widget
+}
+
+func (r *Root) Update() {
+	for _, c := range r.Children {
+		c.Update()
+	}
+}
+
+func (r *Root) Draw(screen *ebiten.Image) {
+	for _, c := range r.Children {
+		c.Draw(screen)
+	}
+}
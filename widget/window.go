@@ -0,0 +1,36 @@
+package widget
+
+import (
+	"image/color"
+
+	"github.com/hajimehoshi/ebiten/v2"
+	"github.com/hajimehoshi/ebiten/v2/text"
+	"github.com/hajimehoshi/ebiten/v2/vector"
+	"golang.org/x/image/font"
+)
+
+// Window is a titled modal panel that draws a background behind its
+// children.
+type Window struct {
+	X, Y, W, H float32
+	Title      string
+	Font       font.Face
+	Children   []Widget
+}
+
+func (w *Window) Update() {
+	for _, c := range w.Children {
+		c.Update()
+	}
+}
+
+func (w *Window) Draw(screen *ebiten.Image) {
+	vector.DrawFilledRect(screen, w.X, w.Y, w.W, w.H, color.RGBA{10, 10, 10, 240}, false)
+	vector.StrokeRect(screen, w.X, w.Y, w.W, w.H, 2, color.RGBA{200, 200, 200, 255}, false)
+	if w.Font != nil && w.Title != "" {
+		text.Draw(screen, w.Title, w.Font, int(w.X)+6, int(w.Y)+16, color.White)
+	}
+	for _, c := range w.Children {
+		c.Draw(screen)
+	}
+}
@@ -0,0 +1,95 @@
+package world
+
+import "math/bits"
+
+// StepConwayFast advances the world by one generation under the standard
+// Conway B3/S23 rule, using a bitpacked half-adder neighbor-count kernel:
+// each chunk row is one uint64 (ChunkSize == 64 bits, one per column), so a
+// chunk's 4096 cells are evolved 64 at a time instead of cell by cell. It is
+// equivalent to evolving every chunk returned by ActiveChunks() with the
+// classic 3x3 scalar scan, just far faster on dense boards; callers with a
+// non-Conway ruleset must fall back to the scalar scan instead.
+func (w *World) StepConwayFast() *World {
+	next := New()
+	for _, coord := range w.ActiveChunks() {
+		chunk, ok := w.chunks[coord]
+		if !ok {
+			chunk = &Chunk{}
+		}
+
+		var rows [ChunkSize + 2]uint64
+		var westBit, eastBit [ChunkSize + 2]uint64
+		for ly := 0; ly < ChunkSize; ly++ {
+			var row uint64
+			for lx := 0; lx < ChunkSize; lx++ {
+				if chunk.Cells[chunk.localIndex(lx, ly)] > 0 {
+					row |= 1 << uint(lx)
+				}
+			}
+			rows[ly+1] = row
+			if w.Get(coord.X*ChunkSize-1, coord.Y*ChunkSize+ly) > 0 {
+				westBit[ly+1] = 1
+			}
+			if w.Get(coord.X*ChunkSize+ChunkSize, coord.Y*ChunkSize+ly) > 0 {
+				eastBit[ly+1] = 1
+			}
+		}
+		for lx := 0; lx < ChunkSize; lx++ {
+			if w.Get(coord.X*ChunkSize+lx, coord.Y*ChunkSize-1) > 0 {
+				rows[0] |= 1 << uint(lx)
+			}
+			if w.Get(coord.X*ChunkSize+lx, coord.Y*ChunkSize+ChunkSize) > 0 {
+				rows[ChunkSize+1] |= 1 << uint(lx)
+			}
+		}
+		if w.Get(coord.X*ChunkSize-1, coord.Y*ChunkSize-1) > 0 {
+			westBit[0] = 1
+		}
+		if w.Get(coord.X*ChunkSize+ChunkSize, coord.Y*ChunkSize-1) > 0 {
+			eastBit[0] = 1
+		}
+		if w.Get(coord.X*ChunkSize-1, coord.Y*ChunkSize+ChunkSize) > 0 {
+			westBit[ChunkSize+1] = 1
+		}
+		if w.Get(coord.X*ChunkSize+ChunkSize, coord.Y*ChunkSize+ChunkSize) > 0 {
+			eastBit[ChunkSize+1] = 1
+		}
+
+		for i := 1; i <= ChunkSize; i++ {
+			top, cur, bot := rows[i-1], rows[i], rows[i+1]
+			topW, topE := (top<<1)|westBit[i-1], (top>>1)|(eastBit[i-1]<<63)
+			curW, curE := (cur<<1)|westBit[i], (cur>>1)|(eastBit[i]<<63)
+			botW, botE := (bot<<1)|westBit[i+1], (bot>>1)|(eastBit[i+1]<<63)
+
+			alive := conwayStepWord(topW, top, topE, curW, cur, curE, botW, bot, botE)
+			ly := i - 1
+			for alive != 0 {
+				lx := bits.TrailingZeros64(alive)
+				alive &= alive - 1
+
+				age := NextAge(chunk.Cells[chunk.localIndex(lx, ly)])
+				next.Set(coord.X*ChunkSize+lx, coord.Y*ChunkSize+ly, age)
+			}
+		}
+	}
+	return next
+}
+
+// conwayStepWord computes one generation of 64 cells under B3/S23, given the
+// cell's own bitplane (cur) and its 8 neighbor bitplanes, one bit per column.
+// It sums the 8 neighbor planes into a 3-bit (mod 8) counter (s0, s1, s2) by
+// ripple-carry adding each plane in turn, then applies the Conway rule
+// directly on the counter bits: a cell is alive next iff its neighbor count
+// is exactly 3, or is 2 and it is already alive; counts of 4 or more are
+// excluded by requiring the top bitplane to be zero.
+func conwayStepWord(nw, n, ne, w, cur, e, sw, s, se uint64) uint64 {
+	var s0, s1, s2 uint64
+	for _, x := range [8]uint64{nw, n, ne, w, e, sw, s, se} {
+		c0 := s0 & x
+		s0 ^= x
+		c1 := s1 & c0
+		s1 ^= c0
+		s2 ^= c1
+	}
+	return ^s2 & s1 & (cur | s0)
+}
@@ -0,0 +1,118 @@
+package world
+
+import (
+	"math/rand"
+	"testing"
+)
+
+// conwayStepScalar is the original cell-by-cell 3x3 scan, kept here only as
+// the baseline for BenchmarkStepScalar; StepConwayFast is its bitpacked
+// replacement.
+func conwayStepScalar(w *World) *World {
+	next := New()
+	for _, coord := range w.ActiveChunks() {
+		for ly := 0; ly < ChunkSize; ly++ {
+			for lx := 0; lx < ChunkSize; lx++ {
+				x := coord.X*ChunkSize + lx
+				y := coord.Y*ChunkSize + ly
+
+				neighbors := 0
+				for dy := -1; dy <= 1; dy++ {
+					for dx := -1; dx <= 1; dx++ {
+						if dx == 0 && dy == 0 {
+							continue
+						}
+						if w.Get(x+dx, y+dy) > 0 {
+							neighbors++
+						}
+					}
+				}
+
+				age := w.Get(x, y)
+				alive := age > 0
+				born := (alive && (neighbors == 2 || neighbors == 3)) || (!alive && neighbors == 3)
+				switch {
+				case born && alive:
+					next.Set(x, y, age+1)
+				case born:
+					next.Set(x, y, 1)
+				}
+			}
+		}
+	}
+	return next
+}
+
+// denseRandomWorld fills a chunksWide x chunksHigh grid of chunks with cells
+// alive with the given probability, dense enough that ActiveChunks() can't
+// skip anything.
+func denseRandomWorld(chunksWide, chunksHigh int, aliveProb float64, seed int64) *World {
+	r := rand.New(rand.NewSource(seed))
+	w := New()
+	for cy := 0; cy < chunksHigh; cy++ {
+		for cx := 0; cx < chunksWide; cx++ {
+			for ly := 0; ly < ChunkSize; ly++ {
+				for lx := 0; lx < ChunkSize; lx++ {
+					if r.Float64() < aliveProb {
+						w.Set(cx*ChunkSize+lx, cy*ChunkSize+ly, 1)
+					}
+				}
+			}
+		}
+	}
+	return w
+}
+
+// TestStepConwayFastMatchesScalar checks the bitpacked kernel against the
+// scalar reference on several dense random boards, including chunk
+// boundaries where neighbor bits must be pulled from adjacent chunks.
+func TestStepConwayFastMatchesScalar(t *testing.T) {
+	for seed := int64(0); seed < 5; seed++ {
+		w := denseRandomWorld(3, 3, 0.4, seed)
+		want := conwayStepScalar(w)
+		got := w.StepConwayFast()
+
+		minX, minY, maxX, maxY, ok := want.Bounds()
+		gotMinX, gotMinY, gotMaxX, gotMaxY, gotOk := got.Bounds()
+		if ok != gotOk {
+			t.Fatalf("seed %d: Bounds ok mismatch: want %v got %v", seed, ok, gotOk)
+		}
+		if !ok {
+			continue
+		}
+		if minX != gotMinX || minY != gotMinY || maxX != gotMaxX || maxY != gotMaxY {
+			t.Fatalf("seed %d: bounds mismatch: want (%d,%d)-(%d,%d) got (%d,%d)-(%d,%d)",
+				seed, minX, minY, maxX, maxY, gotMinX, gotMinY, gotMaxX, gotMaxY)
+		}
+		for y := minY; y <= maxY; y++ {
+			for x := minX; x <= maxX; x++ {
+				if want.Get(x, y) != got.Get(x, y) {
+					t.Fatalf("seed %d: cell (%d,%d): want age %d, got age %d", seed, x, y, want.Get(x, y), got.Get(x, y))
+				}
+			}
+		}
+	}
+}
+
+// benchBoard is roughly 640x480 logical cells (10x8 chunks), dense enough
+// that every chunk stays active for the life of the benchmark.
+const (
+	benchChunksWide = 10
+	benchChunksHigh = 8
+)
+
+func BenchmarkStepScalar(b *testing.B) {
+	w := denseRandomWorld(benchChunksWide, benchChunksHigh, 0.4, 1)
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		w = conwayStepScalar(w)
+	}
+}
+
+func BenchmarkStepConwayFast(b *testing.B) {
+	w := denseRandomWorld(benchChunksWide, benchChunksHigh, 0.4, 1)
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		w = w.StepConwayFast()
+	}
+}
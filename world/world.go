@@ -0,0 +1,179 @@
+// Package world stores a Game of Life board as a sparse grid of fixed-size
+// chunks, so the simulation is not bounded to the size of the window.
+package world
+
+// ChunkSize is the width and height, in cells, of a single chunk.
+const ChunkSize = 64
+
+// MaxAge is the highest age a cell can be stored as. Age increments
+// saturate at MaxAge instead of wrapping, so a long-lived still life can't
+// overflow back to 0 (which reads as dead) after 65535 generations.
+const MaxAge uint16 = 65535
+
+// NextAge returns age+1, saturating at MaxAge instead of wrapping.
+func NextAge(age uint16) uint16 {
+	if age == MaxAge {
+		return MaxAge
+	}
+	return age + 1
+}
+
+// ChunkCoord identifies a chunk by its position in chunk space (i.e. cell
+// coordinates divided by ChunkSize).
+type ChunkCoord struct {
+	X, Y int
+}
+
+// Chunk holds the cell ages for a ChunkSize x ChunkSize tile of the world.
+// alive is a cached flag, true whenever the chunk contains at least one
+// live cell, so the World can skip evolving and drawing empty chunks.
+type Chunk struct {
+	Cells     [ChunkSize * ChunkSize]uint16
+	alive     bool
+	liveCount int
+}
+
+func (c *Chunk) localIndex(lx, ly int) int {
+	return ly*ChunkSize + lx
+}
+
+// World is a sparse, effectively unbounded Game of Life board.
+type World struct {
+	chunks map[ChunkCoord]*Chunk
+}
+
+// New returns an empty World.
+func New() *World {
+	return &World{chunks: make(map[ChunkCoord]*Chunk)}
+}
+
+func chunkLocal(x, y int) (ChunkCoord, int, int) {
+	cx, lx := floorDivMod(x, ChunkSize)
+	cy, ly := floorDivMod(y, ChunkSize)
+	return ChunkCoord{X: cx, Y: cy}, lx, ly
+}
+
+func floorDivMod(a, b int) (q, r int) {
+	q = a / b
+	r = a % b
+	if r < 0 {
+		q--
+		r += b
+	}
+	return q, r
+}
+
+// Get returns the age of the cell at (x, y); 0 if it is dead or its chunk
+// has never been allocated.
+func (w *World) Get(x, y int) uint16 {
+	c, lx, ly := chunkLocal(x, y)
+	chunk, ok := w.chunks[c]
+	if !ok {
+		return 0
+	}
+	return chunk.Cells[chunk.localIndex(lx, ly)]
+}
+
+// Set stores the age of the cell at (x, y), allocating its chunk on demand.
+// Setting a cell to 0 in a chunk that does not exist yet is a no-op.
+func (w *World) Set(x, y int, age uint16) {
+	c, lx, ly := chunkLocal(x, y)
+	chunk, ok := w.chunks[c]
+	if !ok {
+		if age == 0 {
+			return
+		}
+		chunk = &Chunk{}
+		w.chunks[c] = chunk
+	}
+	idx := chunk.localIndex(lx, ly)
+	wasAlive := chunk.Cells[idx] > 0
+	nowAlive := age > 0
+	chunk.Cells[idx] = age
+	switch {
+	case nowAlive && !wasAlive:
+		chunk.liveCount++
+	case wasAlive && !nowAlive:
+		chunk.liveCount--
+	}
+	chunk.alive = chunk.liveCount > 0
+}
+
+// Chunk returns the chunk at coord, and whether it has been allocated.
+func (w *World) Chunk(coord ChunkCoord) (*Chunk, bool) {
+	c, ok := w.chunks[coord]
+	return c, ok
+}
+
+// ActiveChunks returns the coordinates of every chunk that is alive, plus
+// any chunk adjacent to one, since those are the only chunks that can
+// change on the next tick.
+func (w *World) ActiveChunks() []ChunkCoord {
+	active := make(map[ChunkCoord]bool)
+	for coord, chunk := range w.chunks {
+		if !chunk.alive {
+			continue
+		}
+		for dy := -1; dy <= 1; dy++ {
+			for dx := -1; dx <= 1; dx++ {
+				active[ChunkCoord{X: coord.X + dx, Y: coord.Y + dy}] = true
+			}
+		}
+	}
+	coords := make([]ChunkCoord, 0, len(active))
+	for coord := range active {
+		coords = append(coords, coord)
+	}
+	return coords
+}
+
+// Bounds returns the bounding box, in cell coordinates, of every live cell.
+// ok is false if the world has no live cells.
+func (w *World) Bounds() (minX, minY, maxX, maxY int, ok bool) {
+	for coord, chunk := range w.chunks {
+		if !chunk.alive {
+			continue
+		}
+		for ly := 0; ly < ChunkSize; ly++ {
+			for lx := 0; lx < ChunkSize; lx++ {
+				if chunk.Cells[chunk.localIndex(lx, ly)] == 0 {
+					continue
+				}
+				x := coord.X*ChunkSize + lx
+				y := coord.Y*ChunkSize + ly
+				if !ok {
+					minX, minY, maxX, maxY = x, y, x, y
+					ok = true
+					continue
+				}
+				if x < minX {
+					minX = x
+				}
+				if x > maxX {
+					maxX = x
+				}
+				if y < minY {
+					minY = y
+				}
+				if y > maxY {
+					maxY = y
+				}
+			}
+		}
+	}
+	return minX, minY, maxX, maxY, ok
+}
+
+// Prune deletes chunks that no longer contain any live cells. Stepping
+// already rebuilds the world from scratch each generation and so never
+// retains an empty chunk on its own; Prune exists for callers that mutate a
+// World in place (e.g. an editor erasing cells while the simulation is
+// paused) and want to reclaim those chunks without waiting for the next
+// step.
+func (w *World) Prune() {
+	for coord, chunk := range w.chunks {
+		if !chunk.alive {
+			delete(w.chunks, coord)
+		}
+	}
+}